@@ -1,24 +1,19 @@
 package storage
 
 import (
-	"errors"
 	"fmt"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
 	"go.uber.org/zap"
 )
 
 type Engine struct {
-	cold                 *ColdStorageService
+	cold                 storer.Storer
 	hot                  *HotStorageService
 	autoCreateCollection bool
 }
 
-func NewEngine(dataDir string, autoCreate bool) (*Engine, error) {
-	// create backing ColdStorageService
-	cold, err := NewColdStorageService(dataDir)
-	if err != nil {
-		return nil, err
-	}
-
+func NewEngine(cold storer.Storer, autoCreate bool) (*Engine, error) {
 	// create backing HotStorageService
 	hot, err := NewHotStorageService()
 	if err != nil {
@@ -38,7 +33,7 @@ func NewEngine(dataDir string, autoCreate bool) (*Engine, error) {
 // list all distinct collections across both hot and cold storage
 func (e *Engine) ListCollections() ([]string, error) {
 	hotCollections := e.hot.ListCollections()
-	coldCollections, err := e.cold.ListCollections()
+	coldCollections, err := e.cold.List()
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +60,55 @@ func (e *Engine) ListCollections() ([]string, error) {
 	return uniqueIds, nil
 }
 
+// ListCollectionsStream streams all distinct collection ids across both hot
+// and cold storage, lazily, so a large cold tier doesn't need to be fully
+// materialised before the caller sees anything. It stops as soon as done is
+// closed. Any cold storage error is sent on the returned error channel.
+func (e *Engine) ListCollectionsStream(done <-chan struct{}) (<-chan string, <-chan error) {
+	out := make(chan string)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		seen := make(map[string]bool)
+
+		for id := range e.hot.ListCollectionsStream(done) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			select {
+			case out <- id:
+			case <-done:
+				return
+			}
+		}
+
+		coldIds, coldErrCh := e.cold.ListStream(done)
+		for id := range coldIds {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			select {
+			case out <- id:
+			case <-done:
+				return
+			}
+		}
+
+		if err, ok := <-coldErrCh; ok && err != nil {
+			errCh <- err
+		}
+	}()
+
+	return out, errCh
+}
+
 // check if a collection exists across hot and cold storage for a given collection id
 func (e *Engine) CollectionExists(id string) (bool, error) {
 	// first check if collection exists hot
@@ -73,7 +117,7 @@ func (e *Engine) CollectionExists(id string) (bool, error) {
 	}
 
 	// if not, check if collection exists cold, as this is slower due to requiring filesystem io
-	exists, err := e.cold.CollectionExists(id)
+	exists, err := e.cold.Exists(id)
 	if err != nil {
 		return false, err
 	}
@@ -94,25 +138,25 @@ func (e *Engine) IsHot(id string) (bool, error) {
 		return e.hot.CollectionExists(id), nil
 	}
 
-	return false, errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot or cold storage", id))
+	return false, fmt.Errorf("no collection found for collection id [%s] in hot or cold storage: %w", id, storer.ErrCollectionNotFound)
 }
 
 func (e *Engine) LoadCollection(id string) error {
-	collection, err := e.cold.ReadFromDiskForId(id)
+	collection, err := e.cold.Load(id)
 	if err != nil {
 		return err
 	}
 
-	e.hot.collections[id] = collection
+	e.hot.SetCollection(id, collection)
 
 	return nil
 }
 
 func (e *Engine) FlushCollection(id string) error {
 	// check that collection is hot
-	if e.hot.CollectionExists(id) {
+	if collection, exists := e.hot.GetCollection(id); exists {
 		// flush collection to disk
-		err := e.cold.FlushToDisk(id, e.hot.collections[id])
+		err := e.cold.Save(id, collection)
 		if err != nil {
 			return err
 		}
@@ -122,12 +166,12 @@ func (e *Engine) FlushCollection(id string) error {
 	}
 
 	// return error if collection does not exist
-	return errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot or cold storage", id))
+	return fmt.Errorf("no collection found for collection id [%s] in hot or cold storage: %w", id, storer.ErrCollectionNotFound)
 }
 
 func (e *Engine) FlushAllCollections() []error {
 	var errs []error
-	for id := range e.hot.collections {
+	for _, id := range e.hot.ListCollections() {
 		if err := e.FlushCollection(id); err != nil {
 			errs = append(errs, err)
 		}
@@ -148,7 +192,7 @@ func (e *Engine) ReadKey(id, key string) (string, error) {
 	}
 
 	// if collection does not exist in hot storage, check cold storage
-	exists, err := e.cold.CollectionExists(id)
+	exists, err := e.cold.Exists(id)
 	if err != nil {
 		return "", err
 	}
@@ -169,7 +213,7 @@ func (e *Engine) ReadKey(id, key string) (string, error) {
 	}
 
 	// return "", err if collection does not exist in either hot or cold storage
-	return "", errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot or cold storage", id))
+	return "", fmt.Errorf("no collection found for collection id [%s] in hot or cold storage: %w", id, storer.ErrCollectionNotFound)
 }
 
 func (e *Engine) WriteKey(id, key, value string) error {
@@ -191,7 +235,7 @@ func (e *Engine) LoadCollectionIfNotPresent(id string) error {
 	}
 
 	// check if collection exists cold, and load it if it does
-	exists, err := e.cold.CollectionExists(id)
+	exists, err := e.cold.Exists(id)
 	if err != nil {
 		return err
 	}
@@ -218,5 +262,5 @@ func (e *Engine) LoadCollectionIfNotPresent(id string) error {
 	}
 
 	// return error if collection is not present or newly created for given id in hot storage
-	return errors.New(fmt.Sprintf("Unable to find a collection to load into hot storage with id [%s].", id))
+	return fmt.Errorf("unable to find a collection to load into hot storage with id [%s]: %w", id, storer.ErrCollectionNotFound)
 }
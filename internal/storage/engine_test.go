@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/memory"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+)
+
+// slowStorer wraps a storer.Storer and sleeps before Save, widening the
+// window between HotStorageService.GetCollection returning and the
+// collection actually being persisted, so a concurrent WriteKey on the same
+// id is likely to land inside it.
+type slowStorer struct {
+	storer.Storer
+}
+
+func (s slowStorer) Save(id string, data storer.Collection) error {
+	time.Sleep(5 * time.Millisecond)
+	return s.Storer.Save(id, data)
+}
+
+// TestEngineListCollectionsStreamDedupesHotAndCold checks that a collection
+// present in both hot and cold storage is only streamed once.
+func TestEngineListCollectionsStreamDedupesHotAndCold(t *testing.T) {
+	cold := memory.NewStorer()
+	if err := cold.Save("shared", storer.Collection{"k": "v"}); err != nil {
+		t.Fatalf("cold.Save: %v", err)
+	}
+	if err := cold.Save("cold-only", storer.Collection{"k": "v"}); err != nil {
+		t.Fatalf("cold.Save: %v", err)
+	}
+
+	engine, err := NewEngine(cold, false)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	// load "shared" into hot storage too, so it exists in both tiers
+	if err := engine.LoadCollection("shared"); err != nil {
+		t.Fatalf("LoadCollection: %v", err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	ids, errCh := engine.ListCollectionsStream(done)
+	seen := make(map[string]int)
+	for id := range ids {
+		seen[id]++
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("ListCollectionsStream error: %v", err)
+	}
+
+	if seen["shared"] != 1 {
+		t.Fatalf("shared streamed %d times, want 1", seen["shared"])
+	}
+	if seen["cold-only"] != 1 {
+		t.Fatalf("cold-only streamed %d times, want 1", seen["cold-only"])
+	}
+	if len(seen) != 2 {
+		t.Fatalf("streamed ids = %v, want exactly [shared, cold-only]", seen)
+	}
+}
+
+// TestEngineFlushCollectionConcurrentWithWriteKey runs FlushCollection
+// concurrently with WriteKey on the same collection id. Run with -race, it
+// catches the "concurrent map iteration and map write" race that a previous,
+// unprotected HotStorageService.GetCollection was prone to: FlushCollection
+// would hand the live internal map to cold.Save outside any lock, racing a
+// concurrent WriteKey mutating that same map.
+func TestEngineFlushCollectionConcurrentWithWriteKey(t *testing.T) {
+	cold := slowStorer{memory.NewStorer()}
+	engine, err := NewEngine(cold, true)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	if err := engine.hot.CreateCollection("c"); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_ = engine.hot.WriteKey("c", "key", "value")
+		}
+	}()
+
+	for i := 0; i < 25; i++ {
+		// FlushCollection drops "c" from hot storage, so recreate it each
+		// time to keep racing the writer goroutine above.
+		if !engine.hot.CollectionExists("c") {
+			if err := engine.hot.CreateCollection("c"); err != nil {
+				t.Fatalf("CreateCollection: %v", err)
+			}
+		}
+		if err := engine.FlushCollection("c"); err != nil {
+			t.Fatalf("FlushCollection: %v", err)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,243 @@
+// Package filesystem implements storer.Storer backed by gob-encoded .tsdata
+// files in a single directory on the local disk.
+package filesystem
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+	"go.uber.org/zap"
+)
+
+// listStreamBatchSize caps how many directory entries ListStream reads per
+// Readdir call, so a large data directory is walked incrementally rather
+// than read into memory all at once.
+const listStreamBatchSize = 256
+
+// Storer is a storer.Storer backed by gob-encoded .tsdata files in a single
+// directory on the local disk.
+type Storer struct {
+	dataDir string
+}
+
+// NewStorer creates a filesystem-backed Storer rooted at dataDir, creating
+// the directory if it does not already exist.
+func NewStorer(dataDir string) (*Storer, error) {
+	// validate data directory path
+	dataDirPath, err := filepath.Abs(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// create data directory if it doesn't already exist
+	zap.S().Infof("Checking if data directory already exists at [%s].", dataDirPath)
+	if _, err := os.Stat(dataDirPath); errors.Is(err, os.ErrNotExist) {
+		zap.S().Infof("Creating data directory at [%s].", dataDirPath)
+		err = os.Mkdir(dataDirPath, os.ModePerm)
+		if err != nil {
+			zap.S().Errorf("Failed to create data directory at [%s].", dataDirPath)
+			return nil, err
+		}
+
+		zap.S().Infof("Successfully created data directory at [%s].", dataDirPath)
+	} else {
+		zap.S().Infof("Existing data directory found at [%s].", dataDirPath)
+	}
+
+	zap.S().Infof("Launching storage service with data directory [%s].", dataDirPath)
+
+	s := &Storer{
+		dataDir: dataDirPath,
+	}
+
+	return s, nil
+}
+
+func (s *Storer) List() ([]string, error) {
+	// open data directory
+	f, err := os.Open(s.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			zap.S().Error(err)
+		}
+	}()
+
+	// list children for data directory
+	children, err := f.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// filter out directories and files without .tsdata file extension
+	fileNames := make([]string, 0)
+	for _, child := range children {
+		if !child.IsDir() {
+			match, err := regexp.MatchString("[a-zA-Z0-9]+.tsdata", child.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			if match {
+				fileNames = append(fileNames, strings.Split(child.Name(), ".tsdata")[0])
+			}
+		}
+	}
+
+	return fileNames, nil
+}
+
+// ListStream walks the data directory lazily, sending matching collection
+// ids onto the returned channel until either the walk completes or done is
+// closed.
+func (s *Storer) ListStream(done <-chan struct{}) (<-chan string, <-chan error) {
+	out := make(chan string)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		f, err := os.Open(s.dataDir)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				zap.S().Error(err)
+			}
+		}()
+
+		for {
+			children, err := f.Readdir(listStreamBatchSize)
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				errCh <- err
+				return
+			}
+
+			for _, child := range children {
+				if child.IsDir() {
+					continue
+				}
+
+				match, err := regexp.MatchString("[a-zA-Z0-9]+.tsdata", child.Name())
+				if err != nil {
+					errCh <- err
+					return
+				}
+				if !match {
+					continue
+				}
+
+				select {
+				case out <- strings.Split(child.Name(), ".tsdata")[0]:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return out, errCh
+}
+
+func (s *Storer) Exists(id string) (bool, error) {
+	// build absolute filepath for data file
+	dataFilePath := filepath.Join(s.dataDir, fmt.Sprintf("%s.tsdata", id))
+
+	// check if file exists
+	info, err := os.Stat(dataFilePath)
+	if err != nil {
+		// return false, nil if file doesn't exist
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		// return false, err if any other error has been encountered
+		return false, err
+	}
+
+	if info.IsDir() {
+		// return false, err is file is a directory
+		return false, fmt.Errorf("file [%s] is a directory when it should be a normal file: %w", dataFilePath, storer.ErrCollectionIsDirectory)
+	}
+
+	// return true, nil if file is a valid data file
+	return true, nil
+}
+
+func (s *Storer) Load(id string) (storer.Collection, error) {
+	zap.S().Info("Reading collection from disk.")
+
+	// open data file
+	f, err := os.OpenFile(filepath.Join(s.dataDir, fmt.Sprintf("%s.tsdata", id)), os.O_RDONLY, 0755)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no data file found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+		}
+
+		return nil, err
+	}
+	defer func() {
+		err = f.Close()
+		if err != nil {
+			zap.S().Error(err)
+		}
+	}()
+
+	// deserialise binary data file to return type
+	var data storer.Collection
+	if err = gob.NewDecoder(f).Decode(&data); err != nil {
+		zap.S().Errorf("error in reading data file for collection [%s]: %s", id, err.Error())
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s *Storer) Save(id string, data storer.Collection) error {
+	zap.S().Info("Flushing collection to disk.")
+
+	// open data file
+	f, err := os.OpenFile(filepath.Join(s.dataDir, fmt.Sprintf("%s.tsdata", id)), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = f.Close()
+		if err != nil {
+			zap.S().Error(err)
+		}
+	}()
+
+	// serialise data to binary and overwrite data file
+	err = gob.NewEncoder(f).Encode(data)
+	return err
+}
+
+func (s *Storer) Delete(id string) error {
+	zap.S().Info("Deleting collection from disk.")
+
+	if err := os.Remove(filepath.Join(s.dataDir, fmt.Sprintf("%s.tsdata", id))); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no data file found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/filesystem"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer/storertest"
+)
+
+func TestStorerConformance(t *testing.T) {
+	storertest.RunConformance(t, func(t *testing.T) storer.Storer {
+		s, err := filesystem.NewStorer(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewStorer: %v", err)
+		}
+
+		return s
+	})
+}
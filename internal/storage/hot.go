@@ -1,23 +1,30 @@
 package storage
 
 import (
-	"errors"
 	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
 )
 
 type HotStorageService struct {
-	collections map[string]Collection
+	mu          sync.RWMutex
+	collections map[string]storer.Collection
 }
 
 func NewHotStorageService() (*HotStorageService, error) {
 	svc := &HotStorageService{
-		collections: make(map[string]Collection),
+		collections: make(map[string]storer.Collection),
 	}
 
 	return svc, nil
 }
 
 func (svc *HotStorageService) ListCollections() []string {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
 	var ids []string
 	for id := range svc.collections {
 		ids = append(ids, id)
@@ -26,13 +33,41 @@ func (svc *HotStorageService) ListCollections() []string {
 	return ids
 }
 
+// ListCollectionsStream sends every collection id held in hot storage onto
+// the returned channel until either all ids have been sent or done is
+// closed. The id list is snapshotted under lock up front, so a slow
+// consumer doesn't hold svc.mu for the lifetime of the stream.
+func (svc *HotStorageService) ListCollectionsStream(done <-chan struct{}) <-chan string {
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+
+		for _, id := range svc.ListCollections() {
+			select {
+			case out <- id:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
 func (svc *HotStorageService) CollectionExists(id string) bool {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
 	_, exists := svc.collections[id]
 
 	return exists
 }
 
 func (svc *HotStorageService) ReadKey(id, key string) (string, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
 	if collection, exists := svc.collections[id]; exists {
 		// return value for given key from collection given it exists
 		if value, exists := collection[key]; exists {
@@ -40,14 +75,17 @@ func (svc *HotStorageService) ReadKey(id, key string) (string, error) {
 		}
 
 		// return "", err if value does not exist for given key in collection
-		return "", errors.New(fmt.Sprintf("No value found for key [%s] in hot collection [%s].", key, id))
+		return "", fmt.Errorf("no value found for key [%s] in hot collection [%s]: %w", key, id, storer.ErrKeyNotFound)
 	}
 
 	// return "", err if collection does not exist
-	return "", errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot storage.", id))
+	return "", fmt.Errorf("no collection found for collection id [%s] in hot storage: %w", id, storer.ErrCollectionNotFound)
 }
 
 func (svc *HotStorageService) WriteKey(id, key, value string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
 	if collection, exists := svc.collections[id]; exists {
 		// write to collection if it exists
 		collection[key] = value
@@ -56,22 +94,28 @@ func (svc *HotStorageService) WriteKey(id, key, value string) error {
 	}
 
 	// return error if collection does not exist
-	return errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot storage.", id))
+	return fmt.Errorf("no collection found for collection id [%s] in hot storage: %w", id, storer.ErrCollectionNotFound)
 }
 
 func (svc *HotStorageService) CreateCollection(id string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
 	// return error if collection already exists
-	if svc.CollectionExists(id) {
-		return errors.New(fmt.Sprintf("Collection already exists with id [%s] in hot storage.", id))
+	if _, exists := svc.collections[id]; exists {
+		return fmt.Errorf("collection already exists with id [%s] in hot storage: %w", id, storer.ErrCollectionExists)
 	}
 
 	// create collection
-	svc.collections[id] = Collection{}
+	svc.collections[id] = storer.Collection{}
 
 	return nil
 }
 
 func (svc *HotStorageService) DropCollection(id string) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
 	// check if collection exists
 	if _, exists := svc.collections[id]; exists {
 		// drop collection
@@ -81,5 +125,30 @@ func (svc *HotStorageService) DropCollection(id string) error {
 	}
 
 	// return error if collection does not exist
-	return errors.New(fmt.Sprintf("No collection found for collection id [%s] in hot storage.", id))
+	return fmt.Errorf("no collection found for collection id [%s] in hot storage: %w", id, storer.ErrCollectionNotFound)
+}
+
+// SetCollection stores data in hot storage under id, overwriting any
+// existing collection with that id.
+func (svc *HotStorageService) SetCollection(id string, data storer.Collection) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	svc.collections[id] = data
+}
+
+// GetCollection returns a copy of the collection stored in hot storage under
+// id, and whether it exists. A copy is returned, rather than the stored map
+// itself, so the caller can read it after releasing svc.mu without racing a
+// concurrent WriteKey on the same id.
+func (svc *HotStorageService) GetCollection(id string) (storer.Collection, bool) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
+	collection, exists := svc.collections[id]
+	if !exists {
+		return nil, false
+	}
+
+	return maps.Clone(collection), true
 }
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestHotStorageServiceConcurrentStreamAndMutation drains
+// ListCollectionsStream concurrently with writers mutating hot storage. Run
+// with -race, it catches the "concurrent map iteration and map write" crash
+// a previous, unsynchronized version of HotStorageService was prone to.
+func TestHotStorageServiceConcurrentStreamAndMutation(t *testing.T) {
+	svc, err := NewHotStorageService()
+	if err != nil {
+		t.Fatalf("NewHotStorageService: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := svc.CreateCollection(string(rune('a' + i))); err != nil {
+			t.Fatalf("CreateCollection: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	defer close(done)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+
+			id := string(rune('A' + n))
+			for j := 0; j < 50; j++ {
+				_ = svc.CreateCollection(id)
+				_ = svc.WriteKey(id, "key", "value")
+				_ = svc.DropCollection(id)
+			}
+		}(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			streamDone := make(chan struct{})
+			for range svc.ListCollectionsStream(streamDone) {
+			}
+		}()
+	}
+
+	wg.Wait()
+}
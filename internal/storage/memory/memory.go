@@ -0,0 +1,109 @@
+// Package memory implements storer.Storer backed by an in-process map, for
+// use in tests and other scenarios where collections don't need to survive
+// a restart.
+package memory
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+)
+
+// Storer is a storer.Storer backed by an in-process map guarded by a mutex.
+type Storer struct {
+	mu          sync.RWMutex
+	collections map[string]storer.Collection
+}
+
+// NewStorer creates an empty in-memory Storer.
+func NewStorer() *Storer {
+	return &Storer{
+		collections: make(map[string]storer.Collection),
+	}
+}
+
+func (s *Storer) List() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.collections))
+	for id := range s.collections {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ListStream sends every collection id onto the returned channel until
+// either all ids have been sent or done is closed.
+func (s *Storer) ListStream(done <-chan struct{}) (<-chan string, <-chan error) {
+	out := make(chan string)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		s.mu.RLock()
+		ids := make([]string, 0, len(s.collections))
+		for id := range s.collections {
+			ids = append(ids, id)
+		}
+		s.mu.RUnlock()
+
+		for _, id := range ids {
+			select {
+			case out <- id:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, errCh
+}
+
+func (s *Storer) Exists(id string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.collections[id]
+	return exists, nil
+}
+
+func (s *Storer) Load(id string) (storer.Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.collections[id]
+	if !exists {
+		return nil, fmt.Errorf("no collection found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+	}
+
+	// return a copy so the caller can't mutate our stored data by reference
+	return maps.Clone(data), nil
+}
+
+func (s *Storer) Save(id string, data storer.Collection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// store a copy so later mutations to the caller's map don't leak into
+	// our "persisted" copy
+	s.collections[id] = maps.Clone(data)
+	return nil
+}
+
+func (s *Storer) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.collections[id]; !exists {
+		return fmt.Errorf("no collection found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+	}
+
+	delete(s.collections, id)
+	return nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/memory"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer/storertest"
+)
+
+func TestStorerConformance(t *testing.T) {
+	storertest.RunConformance(t, func(t *testing.T) storer.Storer {
+		return memory.NewStorer()
+	})
+}
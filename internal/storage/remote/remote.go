@@ -0,0 +1,260 @@
+// Package remote implements storer.Storer backed by an S3-compatible object
+// store, so long-tail collections can live cheaply outside the local disk.
+// Each collection is stored as one gob-encoded object keyed by
+// "<prefix><id>.tsdata" (the prefix is prepended as-is, so it normally ends
+// in "/"), the same "<id>.tsdata" naming the filesystem backend uses for
+// file names.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+	"go.uber.org/zap"
+)
+
+// Config describes how to reach an S3-compatible bucket used as a cold
+// storage tier.
+type Config struct {
+	// Bucket is the name of the bucket collections are stored in.
+	Bucket string
+
+	// Prefix is prepended to every object key, e.g. "gotsdb/".
+	Prefix string
+
+	// Endpoint overrides the default AWS endpoint, allowing this backend to
+	// target S3-compatible services such as MinIO.
+	Endpoint string
+
+	// Region is the AWS region to use, e.g. "us-east-1".
+	Region string
+
+	// AccessKeyID and SecretAccessKey are static credentials. If either is
+	// empty, the default AWS credential chain (env vars, shared config,
+	// instance role, ...) is used instead.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ConfigFromEnv builds a Config from the GOTSDB_S3_* environment variables.
+func ConfigFromEnv() Config {
+	return Config{
+		Bucket:          os.Getenv("GOTSDB_S3_BUCKET"),
+		Prefix:          os.Getenv("GOTSDB_S3_PREFIX"),
+		Endpoint:        os.Getenv("GOTSDB_S3_ENDPOINT"),
+		Region:          os.Getenv("GOTSDB_S3_REGION"),
+		AccessKeyID:     os.Getenv("GOTSDB_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("GOTSDB_S3_SECRET_ACCESS_KEY"),
+	}
+}
+
+// Storer is a storer.Storer backed by an S3-compatible object store.
+type Storer struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewStorer creates an S3-backed Storer from the given Config.
+func NewStorer(cfg Config) (*Storer, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("remote storer: bucket must not be empty")
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.AccessKeyID != "" && cfg.SecretAccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &Storer{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+func (s *Storer) key(id string) string {
+	return fmt.Sprintf("%s%s.tsdata", s.prefix, id)
+}
+
+func (s *Storer) List() ([]string, error) {
+	ids := make([]string, 0)
+
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range page.Contents {
+			name := strings.TrimPrefix(aws.ToString(obj.Key), s.prefix)
+			if strings.HasSuffix(name, ".tsdata") {
+				ids = append(ids, strings.TrimSuffix(name, ".tsdata"))
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// ListStream pages through the bucket lazily, sending matching collection
+// ids onto the returned channel until either the walk completes or done is
+// closed.
+func (s *Storer) ListStream(done <-chan struct{}) (<-chan string, <-chan error) {
+	out := make(chan string)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errCh)
+
+		paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+			Bucket: aws.String(s.bucket),
+			Prefix: aws.String(s.prefix),
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(context.Background())
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			for _, obj := range page.Contents {
+				name := strings.TrimPrefix(aws.ToString(obj.Key), s.prefix)
+				if !strings.HasSuffix(name, ".tsdata") {
+					continue
+				}
+
+				select {
+				case out <- strings.TrimSuffix(name, ".tsdata"):
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return out, errCh
+}
+
+func (s *Storer) Exists(id string) (bool, error) {
+	_, err := s.client.HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+	})
+	if err != nil {
+		// return false, nil if the object doesn't exist, the same way the
+		// filesystem backend treats os.ErrNotExist
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Storer) Load(id string) (storer.Collection, error) {
+	zap.S().Info("Reading collection from object storage.")
+
+	out, err := s.client.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("no object found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+		}
+
+		return nil, err
+	}
+	defer func() {
+		if err := out.Body.Close(); err != nil {
+			zap.S().Error(err)
+		}
+	}()
+
+	var data storer.Collection
+	if err := gob.NewDecoder(out.Body).Decode(&data); err != nil {
+		zap.S().Errorf("error in reading object for collection [%s]: %s", id, err.Error())
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s *Storer) Save(id string, data storer.Collection) error {
+	zap.S().Info("Flushing collection to object storage.")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(data); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+		Body:   bytes.NewReader(buf.Bytes()),
+	})
+
+	return err
+}
+
+func (s *Storer) Delete(id string) error {
+	zap.S().Info("Deleting collection from object storage.")
+
+	// DeleteObject succeeds even if the key doesn't exist, so check first to
+	// match the ErrCollectionNotFound contract the other Storer backends
+	// honour
+	exists, err := s.Exists(id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no object found for collection id [%s]: %w", id, storer.ErrCollectionNotFound)
+	}
+
+	_, err = s.client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+	})
+
+	return err
+}
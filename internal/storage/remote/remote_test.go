@@ -0,0 +1,50 @@
+package remote
+
+import "testing"
+
+// The full storer.Storer conformance suite needs a live (or MinIO-backed) S3
+// endpoint and isn't run here; these cases cover the object-key and env
+// parsing logic that doesn't need network access.
+
+func TestStorerKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		prefix string
+		id     string
+		want   string
+	}{
+		{"no prefix", "", "col", "col.tsdata"},
+		{"with prefix", "gotsdb/", "col", "gotsdb/col.tsdata"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &Storer{prefix: c.prefix}
+			if got := s.key(c.id); got != c.want {
+				t.Fatalf("key(%q) = %q, want %q", c.id, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("GOTSDB_S3_BUCKET", "my-bucket")
+	t.Setenv("GOTSDB_S3_PREFIX", "gotsdb/")
+	t.Setenv("GOTSDB_S3_ENDPOINT", "http://localhost:9000")
+	t.Setenv("GOTSDB_S3_REGION", "us-east-1")
+
+	cfg := ConfigFromEnv()
+
+	if cfg.Bucket != "my-bucket" {
+		t.Errorf("Bucket = %q, want %q", cfg.Bucket, "my-bucket")
+	}
+	if cfg.Prefix != "gotsdb/" {
+		t.Errorf("Prefix = %q, want %q", cfg.Prefix, "gotsdb/")
+	}
+	if cfg.Endpoint != "http://localhost:9000" {
+		t.Errorf("Endpoint = %q, want %q", cfg.Endpoint, "http://localhost:9000")
+	}
+	if cfg.Region != "us-east-1" {
+		t.Errorf("Region = %q, want %q", cfg.Region, "us-east-1")
+	}
+}
@@ -0,0 +1,26 @@
+package storer
+
+import "errors"
+
+// Sentinel errors returned by Storer implementations and the Engine built on
+// top of them. Callers should classify errors with errors.Is; implementations
+// must wrap these with %w rather than returning unrelated error values, so
+// classification keeps working no matter how many layers the error is
+// wrapped through.
+var (
+	// ErrCollectionNotFound is returned when a collection does not exist for
+	// the requested id.
+	ErrCollectionNotFound = errors.New("collection not found")
+
+	// ErrCollectionExists is returned when a caller tries to create a
+	// collection for an id that already exists.
+	ErrCollectionExists = errors.New("collection already exists")
+
+	// ErrCollectionIsDirectory is returned when a collection's backing path
+	// exists but is a directory rather than a regular file or object.
+	ErrCollectionIsDirectory = errors.New("collection path is a directory")
+
+	// ErrKeyNotFound is returned when a key does not exist within an
+	// otherwise-valid collection.
+	ErrKeyNotFound = errors.New("key not found")
+)
@@ -0,0 +1,36 @@
+// Package storer defines the contract cold-storage backends must implement,
+// along with the in-memory representation of a collection's key/value data.
+package storer
+
+// Collection is an in-memory representation of a single collection's
+// key/value data.
+type Collection map[string]string
+
+// Storer is implemented by cold-storage backends responsible for persisting
+// collections beyond process memory. Implementations exist for the local
+// filesystem (storage/filesystem), S3-compatible object stores
+// (storage/remote) and tests (storage/memory); further backends (embedded
+// databases, ...) can be added without Engine needing to change.
+type Storer interface {
+	// List returns the ids of all collections currently held by the backend.
+	List() ([]string, error)
+
+	// Exists reports whether a collection exists for the given id.
+	Exists(id string) (bool, error)
+
+	// Load reads and returns the collection stored for the given id.
+	Load(id string) (Collection, error)
+
+	// Save persists the given collection under the given id, overwriting any
+	// existing data.
+	Save(id string, data Collection) error
+
+	// Delete removes the collection stored for the given id.
+	Delete(id string) error
+
+	// ListStream lazily walks the backend's collections, sending ids onto
+	// the returned channel as they're discovered. It stops as soon as done
+	// is closed. Any error encountered is sent on the returned error channel,
+	// which is closed once the walk finishes (successfully or not).
+	ListStream(done <-chan struct{}) (<-chan string, <-chan error)
+}
@@ -0,0 +1,224 @@
+// Package storertest provides a shared behavioural test suite that any
+// storer.Storer implementation can run against, so the filesystem, memory,
+// and future backends all honour the same contract.
+package storertest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+)
+
+// RunConformance exercises the storer.Storer contract against a fresh
+// instance returned by newStorer for each subtest.
+func RunConformance(t *testing.T, newStorer func(t *testing.T) storer.Storer) {
+	t.Helper()
+
+	t.Run("LoadMissingReturnsErrCollectionNotFound", func(t *testing.T) {
+		s := newStorer(t)
+
+		if _, err := s.Load("missing"); !errors.Is(err, storer.ErrCollectionNotFound) {
+			t.Fatalf("Load(missing) error = %v, want errors.Is ErrCollectionNotFound", err)
+		}
+	})
+
+	t.Run("DeleteMissingReturnsErrCollectionNotFound", func(t *testing.T) {
+		s := newStorer(t)
+
+		if err := s.Delete("missing"); !errors.Is(err, storer.ErrCollectionNotFound) {
+			t.Fatalf("Delete(missing) error = %v, want errors.Is ErrCollectionNotFound", err)
+		}
+	})
+
+	t.Run("ExistsIsFalseForMissingCollection", func(t *testing.T) {
+		s := newStorer(t)
+
+		exists, err := s.Exists("missing")
+		if err != nil {
+			t.Fatalf("Exists(missing) error = %v, want nil", err)
+		}
+		if exists {
+			t.Fatalf("Exists(missing) = true, want false")
+		}
+	})
+
+	t.Run("SaveThenLoadRoundTrips", func(t *testing.T) {
+		s := newStorer(t)
+
+		want := storer.Collection{"key": "value"}
+		if err := s.Save("col", want); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		exists, err := s.Exists("col")
+		if err != nil {
+			t.Fatalf("Exists: %v", err)
+		}
+		if !exists {
+			t.Fatalf("Exists(col) = false after Save, want true")
+		}
+
+		got, err := s.Load("col")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if got["key"] != want["key"] {
+			t.Fatalf("Load(col) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("SaveDoesNotAliasCallerMap", func(t *testing.T) {
+		s := newStorer(t)
+
+		data := storer.Collection{"key": "original"}
+		if err := s.Save("col", data); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		// mutate the caller's map after saving; the stored copy must be
+		// unaffected
+		data["key"] = "mutated"
+
+		got, err := s.Load("col")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if got["key"] != "original" {
+			t.Fatalf("Load(col) = %v after mutating caller's map, want unaffected copy", got)
+		}
+	})
+
+	t.Run("LoadDoesNotAliasStoredData", func(t *testing.T) {
+		s := newStorer(t)
+
+		if err := s.Save("col", storer.Collection{"key": "original"}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := s.Load("col")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		got["key"] = "mutated"
+
+		again, err := s.Load("col")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if again["key"] != "original" {
+			t.Fatalf("Load(col) = %v after mutating a previous Load result, want unaffected copy", again)
+		}
+	})
+
+	t.Run("DeleteRemovesCollection", func(t *testing.T) {
+		s := newStorer(t)
+
+		if err := s.Save("col", storer.Collection{"key": "value"}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := s.Delete("col"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		exists, err := s.Exists("col")
+		if err != nil {
+			t.Fatalf("Exists: %v", err)
+		}
+		if exists {
+			t.Fatalf("Exists(col) = true after Delete, want false")
+		}
+	})
+
+	t.Run("ListIncludesSavedCollections", func(t *testing.T) {
+		s := newStorer(t)
+
+		want := []string{"a", "b", "c"}
+		for _, id := range want {
+			if err := s.Save(id, storer.Collection{"key": "value"}); err != nil {
+				t.Fatalf("Save(%s): %v", id, err)
+			}
+		}
+
+		got, err := s.List()
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+
+		sort.Strings(got)
+		if len(got) != len(want) {
+			t.Fatalf("List = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("List = %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("ListStreamMatchesList", func(t *testing.T) {
+		s := newStorer(t)
+
+		want := []string{"a", "b", "c"}
+		for _, id := range want {
+			if err := s.Save(id, storer.Collection{"key": "value"}); err != nil {
+				t.Fatalf("Save(%s): %v", id, err)
+			}
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+
+		idCh, errCh := s.ListStream(done)
+
+		var got []string
+		for id := range idCh {
+			got = append(got, id)
+		}
+		if err, ok := <-errCh; ok && err != nil {
+			t.Fatalf("ListStream error: %v", err)
+		}
+
+		sort.Strings(got)
+		if len(got) != len(want) {
+			t.Fatalf("ListStream = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("ListStream = %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("ListStreamStopsWhenDoneCloses", func(t *testing.T) {
+		s := newStorer(t)
+
+		for _, id := range []string{"a", "b", "c"} {
+			if err := s.Save(id, storer.Collection{"key": "value"}); err != nil {
+				t.Fatalf("Save(%s): %v", id, err)
+			}
+		}
+
+		done := make(chan struct{})
+		idCh, _ := s.ListStream(done)
+
+		// take one id then stop reading; the producer must not block forever
+		<-idCh
+		close(done)
+
+		drained := make(chan struct{})
+		go func() {
+			for range idCh {
+			}
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("ListStream did not stop after done was closed")
+		}
+	})
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
 	"github.com/j0shgrant/gotsdb/internal/storage"
+	"github.com/j0shgrant/gotsdb/internal/storage/filesystem"
+	"github.com/j0shgrant/gotsdb/internal/storage/remote"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
 	"go.uber.org/zap"
 	"net/http"
 	"os"
@@ -25,8 +29,12 @@ func main() {
 		_ = logger.Sync()
 	}()
 
-	// create storage service
-	engine, err := storage.NewEngine("data", true)
+	// create cold storage backend and storage service
+	cold, err := newColdStorer()
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+	engine, err := storage.NewEngine(cold, true)
 	if err != nil {
 		zap.S().Fatal(err)
 	}
@@ -34,7 +42,12 @@ func main() {
 	// configure http routing
 	router := mux.NewRouter()
 	router.HandleFunc("/ready", func(_ http.ResponseWriter, _ *http.Request) {})
-	router.HandleFunc("/collections", func(w http.ResponseWriter, _ *http.Request) {
+	router.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/x-ndjson" {
+			streamCollections(w, r, engine)
+			return
+		}
+
 		collections, err := engine.ListCollections()
 		if err != nil {
 			handleServerError(w, err)
@@ -123,13 +136,83 @@ func main() {
 	}
 }
 
+// newColdStorer picks the cold storage backend for the server to use: an S3
+// (or S3-compatible) object store if GOTSDB_S3_BUCKET is set, otherwise the
+// local filesystem under "data".
+func newColdStorer() (storer.Storer, error) {
+	if cfg := remote.ConfigFromEnv(); cfg.Bucket != "" {
+		zap.S().Infof("Using S3 cold storage backend with bucket [%s].", cfg.Bucket)
+		return remote.NewStorer(cfg)
+	}
+
+	return filesystem.NewStorer("data")
+}
+
+// streamCollections writes collection ids to w as newline-delimited JSON as
+// soon as they're discovered, aborting the moment the request is cancelled.
+func streamCollections(w http.ResponseWriter, r *http.Request, engine *storage.Engine) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, canFlush := w.(http.Flusher)
+
+	done := make(chan struct{})
+	go func() {
+		<-r.Context().Done()
+		close(done)
+	}()
+
+	ids, errCh := engine.ListCollectionsStream(done)
+	enc := json.NewEncoder(w)
+	for id := range ids {
+		if err := enc.Encode(map[string]string{"id": id}); err != nil {
+			zap.S().Error(err)
+			return
+		}
+
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+
+	if err, ok := <-errCh; ok && err != nil {
+		zap.S().Error(err)
+	}
+}
+
+// errorClassifications maps sentinel errors from the storage package onto
+// the HTTP status code and error name reported to clients. Checked in order,
+// so classify the most specific sentinels first.
+var errorClassifications = []struct {
+	sentinel error
+	status   int
+	name     string
+}{
+	{storer.ErrCollectionNotFound, http.StatusNotFound, "ErrCollectionNotFound"},
+	{storer.ErrKeyNotFound, http.StatusNotFound, "ErrKeyNotFound"},
+	{storer.ErrCollectionExists, http.StatusConflict, "ErrCollectionExists"},
+	{storer.ErrCollectionIsDirectory, http.StatusInternalServerError, "ErrCollectionIsDirectory"},
+}
+
 func handleServerError(w http.ResponseWriter, err error) {
+	// classify the error into a status code and name via errors.Is, falling
+	// back to a generic internal error for anything unrecognised
+	status := http.StatusInternalServerError
+	name := "ErrInternal"
+	for _, c := range errorClassifications {
+		if errors.Is(err, c.sentinel) {
+			status = c.status
+			name = c.name
+			break
+		}
+	}
+
 	// write header
-	w.WriteHeader(http.StatusInternalServerError)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 
 	// serialise response
 	if err = json.NewEncoder(w).Encode(map[string]interface{}{
-		"code":    http.StatusInternalServerError,
+		"code":    status,
+		"error":   name,
 		"message": err.Error(),
 	}); err != nil {
 		zap.S().Error(err)
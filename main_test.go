@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/j0shgrant/gotsdb/internal/storage"
+	"github.com/j0shgrant/gotsdb/internal/storage/memory"
+	"github.com/j0shgrant/gotsdb/internal/storage/storer"
+)
+
+func TestHandleServerErrorClassifiesSentinels(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantName   string
+	}{
+		{"collection not found", fmt.Errorf("wrap: %w", storer.ErrCollectionNotFound), http.StatusNotFound, "ErrCollectionNotFound"},
+		{"key not found", fmt.Errorf("wrap: %w", storer.ErrKeyNotFound), http.StatusNotFound, "ErrKeyNotFound"},
+		{"collection exists", fmt.Errorf("wrap: %w", storer.ErrCollectionExists), http.StatusConflict, "ErrCollectionExists"},
+		{"collection is directory", fmt.Errorf("wrap: %w", storer.ErrCollectionIsDirectory), http.StatusInternalServerError, "ErrCollectionIsDirectory"},
+		{"unclassified", errors.New("boom"), http.StatusInternalServerError, "ErrInternal"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleServerError(rec, c.err)
+
+			if rec.Code != c.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, c.wantStatus)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode response body: %v", err)
+			}
+			if body["error"] != c.wantName {
+				t.Fatalf("error = %v, want %v", body["error"], c.wantName)
+			}
+			if int(body["code"].(float64)) != c.wantStatus {
+				t.Fatalf("code = %v, want %v", body["code"], c.wantStatus)
+			}
+		})
+	}
+}
+
+// TestHandleServerErrorClassifiesWrappedEngineErrors checks that
+// errors.Is still sees through the wrapping the Engine does on top of a
+// backend's sentinel error, all the way to the HTTP layer.
+func TestHandleServerErrorClassifiesWrappedEngineErrors(t *testing.T) {
+	engine, err := storage.NewEngine(memory.NewStorer(), false)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	_, err = engine.ReadKey("missing", "key")
+	if !errors.Is(err, storer.ErrCollectionNotFound) {
+		t.Fatalf("ReadKey error = %v, want errors.Is ErrCollectionNotFound", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handleServerError(rec, err)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+// TestStreamCollectionsNDJSON exercises streamCollections, which the
+// /collections handler calls for Accept: application/x-ndjson requests. The
+// handler's own Accept check is not exercised here.
+func TestStreamCollectionsNDJSON(t *testing.T) {
+	cold := memory.NewStorer()
+	if err := cold.Save("a", storer.Collection{"k": "v"}); err != nil {
+		t.Fatalf("cold.Save: %v", err)
+	}
+
+	engine, err := storage.NewEngine(cold, false)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/collections", nil).WithContext(ctx)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	streamCollections(rec, req, engine)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+
+	dec := json.NewDecoder(rec.Body)
+	var ids []string
+	for dec.More() {
+		var line map[string]string
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("decode NDJSON line: %v", err)
+		}
+		ids = append(ids, line["id"])
+	}
+
+	if len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("streamed ids = %v, want [a]", ids)
+	}
+}